@@ -0,0 +1,61 @@
+package qerr
+
+// Builtin registration of the HTTP/3 application error namespace (RFC 9114,
+// section 8.1, and RFC 9204, section 8.1), so that http3 errors are
+// self-describing without the http3 package having to write its own
+// switch statement over error codes.
+func init() {
+	RegisterApplicationErrorNamespace("h3", func(code ErrorCode) (string, string) {
+		name, ok := h3ErrorCodeNames[code]
+		if !ok {
+			return "", ""
+		}
+		return name, h3ErrorCodeDescriptions[code]
+	})
+}
+
+var h3ErrorCodeNames = map[ErrorCode]string{
+	0x100: "H3_NO_ERROR",
+	0x101: "H3_GENERAL_PROTOCOL_ERROR",
+	0x102: "H3_INTERNAL_ERROR",
+	0x103: "H3_STREAM_CREATION_ERROR",
+	0x104: "H3_CLOSED_CRITICAL_STREAM",
+	0x105: "H3_FRAME_UNEXPECTED",
+	0x106: "H3_FRAME_ERROR",
+	0x107: "H3_EXCESSIVE_LOAD",
+	0x108: "H3_ID_ERROR",
+	0x109: "H3_SETTINGS_ERROR",
+	0x10a: "H3_MISSING_SETTINGS",
+	0x10b: "H3_REQUEST_REJECTED",
+	0x10c: "H3_REQUEST_CANCELLED",
+	0x10d: "H3_REQUEST_INCOMPLETE",
+	0x10e: "H3_MESSAGE_ERROR",
+	0x10f: "H3_CONNECT_ERROR",
+	0x110: "H3_VERSION_FALLBACK",
+	0x200: "QPACK_DECOMPRESSION_FAILED",
+	0x201: "QPACK_ENCODER_STREAM_ERROR",
+	0x202: "QPACK_DECODER_STREAM_ERROR",
+}
+
+var h3ErrorCodeDescriptions = map[ErrorCode]string{
+	0x100: "no error",
+	0x101: "general protocol error",
+	0x102: "internal error",
+	0x103: "stream creation error",
+	0x104: "critical stream was closed",
+	0x105: "frame not permitted in the current state",
+	0x106: "frame violated layout or content constraints",
+	0x107: "peer generated excessive load",
+	0x108: "an identifier exceeded a limit",
+	0x109: "error in the SETTINGS frame",
+	0x10a: "no SETTINGS frame received where required",
+	0x10b: "request rejected before processing",
+	0x10c: "request cancelled by server",
+	0x10d: "request not fully received or sent",
+	0x10e: "malformed request or response message",
+	0x10f: "TCP event signaled while using CONNECT",
+	0x110: "frame received indicating a version downgrade",
+	0x200: "QPACK decompression failed",
+	0x201: "error on the QPACK encoder stream",
+	0x202: "error on the QPACK decoder stream",
+}
@@ -0,0 +1,54 @@
+package qerr
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RecordOnSpan records a QuicError as an event on the span active on ctx,
+// setting standard semantic attributes for the error code, frame type and
+// error classification, and marking the span as errored. It is a no-op if
+// ctx carries no recording span, so call sites don't need to check whether
+// OTel tracing is enabled before calling it.
+//
+// It is meant to be called from the places a connection or stream is closed
+// with a QuicError, e.g. session.closeLocal and handleCloseFrame, once the
+// user has configured a quic.Config.Tracer. That call-site wiring is not part
+// of this package and does not exist in this tree yet (there is no session
+// package here to wire it into) -- until it's added, RecordOnSpan has to be
+// invoked manually, and closing a connection does not automatically produce
+// span telemetry.
+//
+// TODO(session-wiring): this is the qerr-side building block only. The
+// feature described by the originating request -- automatic span telemetry
+// on every connection/stream close -- isn't complete until session.closeLocal
+// and handleCloseFrame call RecordOnSpan. Don't treat this package alone as
+// "OTel support shipped".
+func RecordOnSpan(ctx context.Context, err *QuicError) {
+	if err == nil {
+		return
+	}
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	// Application error codes share the 0x100-0x1ff range with crypto errors
+	// (e.g. HTTP/3's), so only transport errors are classified as crypto.
+	isCrypto := !err.isApplicationError && err.IsCryptoError()
+	attrs := []attribute.KeyValue{
+		attribute.Int64("quic.error_code", int64(err.ErrorCode)),
+		attribute.Int64("quic.frame_type", int64(err.FrameType)),
+		attribute.Bool("quic.is_application", err.isApplicationError),
+		attribute.Bool("quic.is_crypto", isCrypto),
+		attribute.Bool("quic.is_timeout", err.isTimeout),
+	}
+	if isCrypto {
+		attrs = append(attrs, attribute.Int64("quic.tls_alert", int64(err.ErrorCode-0x100)))
+	}
+	span.SetAttributes(attrs...)
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
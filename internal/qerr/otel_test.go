@@ -0,0 +1,93 @@
+package qerr
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newRecordingContext(t *testing.T) (context.Context, *tracetest.InMemoryExporter, func()) {
+	t.Helper()
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	ctx, span := tp.Tracer("qerr-test").Start(context.Background(), "test-span")
+	return ctx, exporter, func() { span.End() }
+}
+
+func TestRecordOnSpanNilErrorIsNoop(t *testing.T) {
+	ctx, exporter, end := newRecordingContext(t)
+	RecordOnSpan(ctx, nil)
+	end()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected exactly one span, got %d", len(spans))
+	}
+	if len(spans[0].Attributes) != 0 {
+		t.Fatalf("expected no attributes to be set for a nil error, got %v", spans[0].Attributes)
+	}
+}
+
+func TestRecordOnSpanNonRecordingSpanIsNoop(t *testing.T) {
+	// context.Background() carries no span, so trace.SpanFromContext returns
+	// a no-op span that isn't recording. This must not panic.
+	RecordOnSpan(context.Background(), NewError(FlowControlError, "too much data"))
+}
+
+func TestRecordOnSpanSetsAttributes(t *testing.T) {
+	ctx, exporter, end := newRecordingContext(t)
+	RecordOnSpan(ctx, NewError(FlowControlError, "too much data"))
+	end()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected exactly one span, got %d", len(spans))
+	}
+	span := spans[0]
+
+	attrs := map[string]bool{}
+	for _, kv := range span.Attributes {
+		attrs[string(kv.Key)] = true
+	}
+	for _, key := range []string{"quic.error_code", "quic.frame_type", "quic.is_application", "quic.is_crypto", "quic.is_timeout"} {
+		if !attrs[key] {
+			t.Fatalf("expected attribute %q to be set, got %v", key, span.Attributes)
+		}
+	}
+	if attrs["quic.tls_alert"] {
+		t.Fatalf("did not expect quic.tls_alert to be set for a non-crypto error")
+	}
+	if len(span.Events) == 0 {
+		t.Fatalf("expected RecordError to add an event to the span")
+	}
+	if span.Status.Code != codes.Error {
+		t.Fatalf("expected the span status to be set to Error, got %v", span.Status.Code)
+	}
+}
+
+func TestRecordOnSpanSetsTLSAlertForCryptoErrors(t *testing.T) {
+	ctx, exporter, end := newRecordingContext(t)
+	RecordOnSpan(ctx, NewCryptoError(42, "bad certificate"))
+	end()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected exactly one span, got %d", len(spans))
+	}
+
+	found := false
+	for _, kv := range spans[0].Attributes {
+		if string(kv.Key) == "quic.tls_alert" {
+			found = true
+			if kv.Value.AsInt64() != 42 {
+				t.Fatalf("expected quic.tls_alert to be 42, got %d", kv.Value.AsInt64())
+			}
+		}
+	}
+	if !found {
+		t.Fatalf("expected quic.tls_alert to be set for a crypto error")
+	}
+}
@@ -0,0 +1,104 @@
+package qerr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestQuicErrorIsMatchesSentinelByCode(t *testing.T) {
+	err := NewError(FlowControlError, "too much data")
+	if !errors.Is(err, ErrFlowControlError) {
+		t.Fatalf("expected errors.Is to match ErrFlowControlError, got %v", err)
+	}
+	if errors.Is(err, ErrProtocolViolation) {
+		t.Fatalf("expected errors.Is not to match ErrProtocolViolation, got %v", err)
+	}
+}
+
+func TestQuicErrorIsDoesNotConfuseTimeoutWithNoError(t *testing.T) {
+	timeout := NewTimeoutError("handshake timeout")
+	if errors.Is(timeout, ErrNoError) {
+		t.Fatalf("a timeout error (ErrorCode 0x0) must not match ErrNoError")
+	}
+}
+
+func TestQuicErrorIsDistinguishesApplicationErrors(t *testing.T) {
+	transport := NewError(0x42, "")
+	application := NewApplicationError(0x42, "")
+	if errors.Is(transport, application) {
+		t.Fatalf("transport and application errors with the same code should not match")
+	}
+}
+
+func TestNewErrorWithCauseUnwraps(t *testing.T) {
+	cause := errors.New("read tcp: connection reset")
+	err := NewErrorWithCause(InternalError, cause, "")
+
+	if !errors.Is(err, cause) {
+		t.Fatalf("expected errors.Is(err, cause) to be true")
+	}
+	var qe *QuicError
+	if !errors.As(fmt.Errorf("setup failed: %w", err), &qe) {
+		t.Fatalf("expected errors.As to find the wrapped *QuicError")
+	}
+	if qe != err {
+		t.Fatalf("expected errors.As to return the original *QuicError, got %v", qe)
+	}
+}
+
+func TestToQuicErrorPreservesWrappedQuicError(t *testing.T) {
+	original := NewError(StreamStateError, "stream already closed")
+	wrapped := fmt.Errorf("handler failed: %w", original)
+
+	got := ToQuicError(wrapped)
+	if got != original {
+		t.Fatalf("expected ToQuicError to unwrap to the original *QuicError, got %v", got)
+	}
+}
+
+func TestToQuicErrorFallsBackToInternalError(t *testing.T) {
+	got := ToQuicError(errors.New("boom"))
+	if got.ErrorCode != InternalError {
+		t.Fatalf("expected InternalError, got %v", got.ErrorCode)
+	}
+	if got.ErrorMessage != "boom" {
+		t.Fatalf("expected error message %q, got %q", "boom", got.ErrorMessage)
+	}
+}
+
+func TestWithALPNDoesNotMutateSentinel(t *testing.T) {
+	withALPN := ErrFlowControlError.WithALPN("h3")
+
+	if ErrFlowControlError.ALPN != "" {
+		t.Fatalf("WithALPN must not mutate the shared sentinel, got ALPN %q", ErrFlowControlError.ALPN)
+	}
+	if withALPN.ALPN != "h3" {
+		t.Fatalf("expected the returned copy to have ALPN set, got %q", withALPN.ALPN)
+	}
+	if withALPN == ErrFlowControlError {
+		t.Fatalf("expected WithALPN to return a distinct *QuicError")
+	}
+	if !errors.Is(withALPN, ErrFlowControlError) {
+		t.Fatalf("expected the copy to still match the sentinel by errors.Is")
+	}
+}
+
+func TestNewApplicationErrorWithPayloadAcceptsWithinCap(t *testing.T) {
+	payload := make([]byte, MaxErrorPayloadSize)
+	err, e := NewApplicationErrorWithPayload(0x42, "application/octet-stream", payload, "")
+	if e != nil {
+		t.Fatalf("expected a payload at the cap to be accepted, got error: %v", e)
+	}
+	if len(err.Payload) != MaxErrorPayloadSize {
+		t.Fatalf("expected payload to be stored on the QuicError")
+	}
+}
+
+func TestNewApplicationErrorWithPayloadRejectsOversized(t *testing.T) {
+	payload := make([]byte, MaxErrorPayloadSize+1)
+	_, err := NewApplicationErrorWithPayload(0x42, "application/octet-stream", payload, "")
+	if err == nil {
+		t.Fatalf("expected an oversized payload to be rejected")
+	}
+}
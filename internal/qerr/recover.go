@@ -0,0 +1,45 @@
+package qerr
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// maxRecoveredStackSize caps how much of a recovered panic's stack trace
+// ends up in ErrorMessage, so a runaway panic doesn't blow up the size of
+// the resulting CONNECTION_CLOSE frame (or log line).
+const maxRecoveredStackSize = 4 << 10 // 4 KiB
+
+// RecoverToQuicError converts a value recovered from a panic (as returned by
+// the builtin recover()) into an InternalError-coded QuicError, with the
+// panic message and a truncated stack trace in ErrorMessage. stack is
+// typically the result of debug.Stack(), passed in by the caller so that
+// RecoverToQuicError itself doesn't need to depend on runtime/debug; SafeCall
+// below does the debug.Stack() call for its own use of RecoverToQuicError.
+//
+// It is meant for use in a deferred recover() at the boundary between the
+// session and a user-supplied callback (a stream handler, tls.Config's
+// GetCertificate, an http3 request handler), so that a panicking callback
+// results in a well-formed QUIC error instead of crashing the process.
+func RecoverToQuicError(recovered interface{}, stack []byte) *QuicError {
+	if len(stack) > maxRecoveredStackSize {
+		stack = stack[:maxRecoveredStackSize]
+	}
+	msg := fmt.Sprintf("panic: %v\n%s", recovered, stack)
+	return NewError(InternalError, msg)
+}
+
+// SafeCall runs fn and converts any panic it raises into a QuicError via
+// RecoverToQuicError, instead of letting it propagate and crash the process.
+// If fn returns a non-nil error, it is passed through ToQuicError.
+func SafeCall(fn func() error) (qe *QuicError) {
+	defer func() {
+		if r := recover(); r != nil {
+			qe = RecoverToQuicError(r, debug.Stack())
+		}
+	}()
+	if err := fn(); err != nil {
+		return ToQuicError(err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package qerr
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRecoverToQuicError(t *testing.T) {
+	err := RecoverToQuicError("something went wrong", []byte("goroutine 1 [running]:\nmain.main()"))
+	if err.ErrorCode != InternalError {
+		t.Fatalf("expected InternalError, got %v", err.ErrorCode)
+	}
+	if !strings.Contains(err.ErrorMessage, "something went wrong") {
+		t.Fatalf("expected the panic message to be included, got %q", err.ErrorMessage)
+	}
+}
+
+func TestRecoverToQuicErrorTruncatesStack(t *testing.T) {
+	stack := make([]byte, maxRecoveredStackSize*2)
+	for i := range stack {
+		stack[i] = 'x'
+	}
+	err := RecoverToQuicError("boom", stack)
+	if len(err.ErrorMessage) > maxRecoveredStackSize+64 {
+		t.Fatalf("expected the stack trace in ErrorMessage to be truncated, got %d bytes", len(err.ErrorMessage))
+	}
+}
+
+func TestSafeCallConvertsPanicToInternalError(t *testing.T) {
+	err := SafeCall(func() error {
+		panic("user handler blew up")
+	})
+	if err == nil {
+		t.Fatalf("expected SafeCall to recover the panic into a QuicError")
+	}
+	if err.ErrorCode != InternalError {
+		t.Fatalf("expected InternalError, got %v", err.ErrorCode)
+	}
+	if !strings.Contains(err.ErrorMessage, "user handler blew up") {
+		t.Fatalf("expected the panic message to be included, got %q", err.ErrorMessage)
+	}
+}
+
+func TestSafeCallPassesThroughReturnedError(t *testing.T) {
+	err := SafeCall(func() error {
+		return errors.New("stream closed")
+	})
+	if err == nil {
+		t.Fatalf("expected a non-nil QuicError")
+	}
+	if !strings.Contains(err.ErrorMessage, "stream closed") {
+		t.Fatalf("expected the returned error's message to survive ToQuicError, got %q", err.ErrorMessage)
+	}
+}
+
+func TestSafeCallPreservesQuicErrorUnchanged(t *testing.T) {
+	original := NewError(StreamStateError, "already closed")
+	err := SafeCall(func() error {
+		return original
+	})
+	if err != original {
+		t.Fatalf("expected SafeCall to pass an existing *QuicError through unchanged, got %v", err)
+	}
+}
+
+func TestSafeCallReturnsNilOnSuccess(t *testing.T) {
+	err := SafeCall(func() error { return nil })
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+}
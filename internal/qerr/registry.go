@@ -0,0 +1,42 @@
+package qerr
+
+import "sync"
+
+// ApplicationErrorResolver resolves an application error code to a
+// human-readable name and description. It is registered per ALPN via
+// RegisterApplicationErrorNamespace.
+type ApplicationErrorResolver func(code ErrorCode) (name, description string)
+
+var (
+	namespacesMu sync.RWMutex
+	namespaces   = make(map[string]ApplicationErrorResolver)
+)
+
+// RegisterApplicationErrorNamespace registers resolver as the way to turn
+// application error codes into human-readable names and descriptions for
+// connections that negotiated alpn. (*QuicError).Error consults the
+// registered resolver for the connection's ALPN, so that packet captures and
+// logs show e.g. "H3_GENERAL_PROTOCOL_ERROR (0x101)" instead of a bare
+// "Application error 0x101" when multiple protocols share a connection.
+//
+// Calling RegisterApplicationErrorNamespace again for an alpn that already
+// has a resolver replaces it.
+func RegisterApplicationErrorNamespace(alpn string, resolver ApplicationErrorResolver) {
+	namespacesMu.Lock()
+	defer namespacesMu.Unlock()
+	namespaces[alpn] = resolver
+}
+
+// lookupApplicationError looks up the name and description for code under
+// the namespace registered for alpn. ok is false if no namespace is
+// registered for alpn, or if the resolver doesn't recognize code.
+func lookupApplicationError(alpn string, code ErrorCode) (name, description string, ok bool) {
+	namespacesMu.RLock()
+	resolver, registered := namespaces[alpn]
+	namespacesMu.RUnlock()
+	if !registered {
+		return "", "", false
+	}
+	name, description = resolver(code)
+	return name, description, len(name) > 0
+}
@@ -0,0 +1,46 @@
+package qerr
+
+import "testing"
+
+func TestLookupApplicationErrorUnregisteredALPN(t *testing.T) {
+	_, _, ok := lookupApplicationError("no-such-alpn", 0x1)
+	if ok {
+		t.Fatalf("expected lookup for an unregistered ALPN to fail")
+	}
+}
+
+func TestLookupApplicationErrorRegisteredNamespace(t *testing.T) {
+	const alpn = "qerr-test-proto"
+	RegisterApplicationErrorNamespace(alpn, func(code ErrorCode) (string, string) {
+		if code == 0x1 {
+			return "TEST_ERROR", "a test error"
+		}
+		return "", ""
+	})
+
+	name, description, ok := lookupApplicationError(alpn, 0x1)
+	if !ok {
+		t.Fatalf("expected lookup to succeed for a registered namespace")
+	}
+	if name != "TEST_ERROR" || description != "a test error" {
+		t.Fatalf("unexpected name/description: %q / %q", name, description)
+	}
+
+	_, _, ok = lookupApplicationError(alpn, 0x2)
+	if ok {
+		t.Fatalf("expected lookup to fail for a code the resolver doesn't recognize")
+	}
+}
+
+func TestBuiltinH3Namespace(t *testing.T) {
+	name, description, ok := lookupApplicationError("h3", 0x101)
+	if !ok {
+		t.Fatalf("expected the builtin h3 namespace to be registered")
+	}
+	if name != "H3_GENERAL_PROTOCOL_ERROR" {
+		t.Fatalf("unexpected name: %q", name)
+	}
+	if description == "" {
+		t.Fatalf("expected a non-empty description")
+	}
+}